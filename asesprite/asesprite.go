@@ -1,6 +1,7 @@
 package asesprite
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,9 @@ import (
 	_ "image/png"
 	"io/fs"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -21,6 +25,96 @@ type direction string
 // BlendMode is the Asesprite blend mode of a layer.
 type blendMode string
 
+// ebitenBlend maps an Asesprite layer blend mode to the nearest equivalent ebiten.Blend.
+// Unrecognized modes, including "normal", fall back to regular alpha blending.
+func (mode blendMode) ebitenBlend() ebiten.Blend {
+	switch mode {
+	case "multiply":
+		return ebiten.Blend{
+			BlendFactorSourceRGB:        ebiten.BlendFactorDestinationColor,
+			BlendFactorSourceAlpha:      ebiten.BlendFactorOne,
+			BlendFactorDestinationRGB:   ebiten.BlendFactorZero,
+			BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusSourceAlpha,
+			BlendOperationRGB:           ebiten.BlendOperationAdd,
+			BlendOperationAlpha:         ebiten.BlendOperationAdd,
+		}
+	case "screen":
+		return ebiten.Blend{
+			BlendFactorSourceRGB:        ebiten.BlendFactorOne,
+			BlendFactorSourceAlpha:      ebiten.BlendFactorOne,
+			BlendFactorDestinationRGB:   ebiten.BlendFactorOneMinusSourceColor,
+			BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusSourceAlpha,
+			BlendOperationRGB:           ebiten.BlendOperationAdd,
+			BlendOperationAlpha:         ebiten.BlendOperationAdd,
+		}
+	case "addition":
+		return ebiten.BlendLighter
+	case "subtract":
+		return ebiten.Blend{
+			BlendFactorSourceRGB:        ebiten.BlendFactorOne,
+			BlendFactorSourceAlpha:      ebiten.BlendFactorOne,
+			BlendFactorDestinationRGB:   ebiten.BlendFactorOne,
+			BlendFactorDestinationAlpha: ebiten.BlendFactorOneMinusSourceAlpha,
+			BlendOperationRGB:           ebiten.BlendOperationReverseSubtract,
+			BlendOperationAlpha:         ebiten.BlendOperationAdd,
+		}
+	default:
+		return ebiten.BlendSourceOver
+	}
+}
+
+// Options configures how an Asesprite sprite sheet's JSON data is interpreted.
+type Options struct {
+	// SplitLayers indicates the sheet was exported with Aseprite's `--split-layers` flag, so
+	// each frame's filename carries a `(layer name)` suffix identifying which layer it belongs
+	// to, and every animation frame has one entry per layer rather than one flattened entry.
+	// LayeredAnimation requires this to be set.
+	SplitLayers bool
+}
+
+// splitLayerFilenamePattern matches the `(layer name) frameNumber` suffix Aseprite appends to
+// each frame's filename when exporting with `--split-layers`.
+var splitLayerFilenamePattern = regexp.MustCompile(`\(([^)]+)\)\D*(\d+)`)
+
+// parseSplitLayerFilename extracts the layer name and frame number from a split-layers frame
+// filename, such as "hero (body) 0.aseprite".
+func parseSplitLayerFilename(filename string) (layerName string, frameNumber int, ok bool) {
+	matches := splitLayerFilenamePattern.FindStringSubmatch(filename)
+	if matches == nil {
+		return "", 0, false
+	}
+
+	frameNumber, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return matches[1], frameNumber, true
+}
+
+// splitFrameKey identifies a single (layer, frame) combination in a split-layers export.
+type splitFrameKey struct {
+	layer       string
+	frameNumber int
+}
+
+// buildSplitFrames indexes data.Frames by the (layer, frame number) pair parsed from each
+// frame's filename, for sprite sheets exported with `--split-layers`.
+func buildSplitFrames(data *asespriteData) map[splitFrameKey]int {
+	splitFrames := make(map[splitFrameKey]int, len(data.Frames))
+
+	for i, f := range data.Frames {
+		layerName, frameNumber, ok := parseSplitLayerFilename(f.Filename)
+		if !ok {
+			continue
+		}
+
+		splitFrames[splitFrameKey{layer: layerName, frameNumber: frameNumber}] = i
+	}
+
+	return splitFrames
+}
+
 type rectangle struct {
 	coordinates
 	dimensions
@@ -50,8 +144,30 @@ type layer struct {
 	BlendMode blendMode `json:"blendMode"`
 }
 
-// slice represents an Asesprite slice.
-type slice struct {
+// Slice represents an Asesprite slice, such as a 9-patch panel or a named pivot/hitbox region.
+type Slice struct {
+	Name  string     `json:"name"`
+	Color string     `json:"color"`
+	Data  string     `json:"data"`
+	Keys  []SliceKey `json:"keys"`
+}
+
+// SliceKey represents a slice's bounds on a specific frame. A slice may change shape over the
+// course of an animation, so Aseprite records one key per frame at which the slice changes.
+type SliceKey struct {
+	// Frame is the index of the frame this key takes effect on.
+	Frame int `json:"frame"`
+
+	// Bounds is the slice's rectangle on the frame.
+	Bounds rectangle `json:"bounds"`
+
+	// Center is the stretchy middle band used for 9-patch scaling, relative to Bounds. It is
+	// nil when the slice has no 9-patch data.
+	Center *rectangle `json:"center,omitempty"`
+
+	// Pivot is the slice's pivot point, relative to Bounds. It is nil when the slice defines
+	// no pivot.
+	Pivot *coordinates `json:"pivot,omitempty"`
 }
 
 // metadata represents an Asesprite sprite sheet's metadata.
@@ -64,34 +180,175 @@ type metadata struct {
 	Scale       string     `json:"scale"`
 	FrameTags   []frameTag `json:"frameTags"`
 	Layers      []layer    `json:"layers"`
-	Slices      []slice    `json:"slice"`
+	Slices      []Slice    `json:"slices"`
 }
 
 // frame represents a single Asesprite frame in the array-style sprite sheet format.
 type frame struct {
-	Filename         string        `json:"filename"`
-	Frame            rectangle     `json:"frame"`
-	Rotated          bool          `json:"rotated"`
-	Trimmed          bool          `json:"trimmed"`
-	SpriteSourceSize rectangle     `json:"spriteSourceSize"`
-	SourceSize       dimensions    `json:"sourceSize"`
-	Duration         time.Duration `json:"duration"`
+	Filename         string     `json:"filename"`
+	Frame            rectangle  `json:"frame"`
+	Rotated          bool       `json:"rotated"`
+	Trimmed          bool       `json:"trimmed"`
+	SpriteSourceSize rectangle  `json:"spriteSourceSize"`
+	SourceSize       dimensions `json:"sourceSize"`
+	Duration         int        `json:"duration"` // milliseconds
 }
 
-// asespriteData is the top-level entity of an Asesprite sprite sheet data file.  Currently,
-// only sprite sheets exported in the array-style format are supported.
+// asespriteData is the top-level entity of an Asesprite sprite sheet data file.  Both the
+// array-style and hash-style `frames` formats are supported.
 type asespriteData struct {
-	Frames   []frame  `json:"frames"`
-	Metadata metadata `json:"meta"`
+	Frames   []frame
+	Metadata metadata
+}
+
+// UnmarshalJSON dispatches on whether `frames` was exported as a JSON array (array-style) or
+// a JSON object keyed by filename (hash-style).
+func (data *asespriteData) UnmarshalJSON(jsonBytes []byte) error {
+	var raw struct {
+		Frames   json.RawMessage `json:"frames"`
+		Metadata metadata        `json:"meta"`
+	}
+	if err := json.Unmarshal(jsonBytes, &raw); err != nil {
+		return err
+	}
+
+	frames, err := unmarshalFrames(raw.Frames)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal Asesprite frames: %w", err)
+	}
+
+	data.Frames = frames
+	data.Metadata = raw.Metadata
+
+	return nil
+}
+
+// unmarshalFrames unmarshals the `frames` field of an Asesprite sprite sheet, dispatching
+// between the array-style and hash-style formats based on the raw JSON's leading token.
+func unmarshalFrames(rawFrames json.RawMessage) ([]frame, error) {
+	trimmed := bytes.TrimSpace(rawFrames)
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	switch trimmed[0] {
+	case '[':
+		var frames []frame
+		if err := json.Unmarshal(rawFrames, &frames); err != nil {
+			return nil, err
+		}
+		return frames, nil
+	case '{':
+		return unmarshalFramesByFilename(rawFrames)
+	default:
+		return nil, fmt.Errorf("unexpected token %q at start of frames", trimmed[0])
+	}
+}
+
+// unmarshalFramesByFilename unmarshals the hash-style `frames` format, an object keyed by
+// filename. The object's key order is preserved (rather than relying on Go's unordered map
+// iteration) since it is the only indication of original frame order, which frame tags index
+// into by position.
+func unmarshalFramesByFilename(rawFrames json.RawMessage) ([]frame, error) {
+	decoder := json.NewDecoder(bytes.NewReader(rawFrames))
+
+	if _, err := decoder.Token(); err != nil { // consume the opening '{'
+		return nil, err
+	}
+
+	var frames []frame
+	for decoder.More() {
+		nameToken, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var f frame
+		if err := decoder.Decode(&f); err != nil {
+			return nil, err
+		}
+		f.Filename, _ = nameToken.(string)
+
+		frames = append(frames, f)
+	}
+
+	return frames, nil
 }
 
 // AsespriteSpriteSheet is the logical grouping of an Asesprite sprite sheet's image and its
 // accompanying data.
 type AsespriteSpriteSheet struct {
-	data           *asespriteData
-	image          *ebiten.Image
-	imageCache     []*ebiten.Image
-	animationCache map[string]*sprites.Animation
+	data                  *asespriteData
+	image                 *ebiten.Image
+	imageCache            []*ebiten.Image
+	animationCache        map[string]*sprites.Animation
+	animationRangeCache   map[animationRangeKey]*sprites.Animation
+	sliceCache            map[string]*Slice
+	options               Options
+	splitFrames           map[splitFrameKey]int
+	layeredAnimationCache map[string]*sprites.LayeredAnimation
+}
+
+// animationRangeKey identifies a cached sub-range animation carved out of a named tag's frames.
+type animationRangeKey struct {
+	name     string
+	from, to int
+}
+
+// FrameCount returns the number of raw frames in the sprite sheet's `frames` data, independent
+// of any tags.
+func (spritesheet *AsespriteSpriteSheet) FrameCount() int {
+	return len(spritesheet.data.Frames)
+}
+
+// FrameImage returns the sub-image of the sprite sheet for the raw frame at index i (not a
+// tagged animation's frame index), populating the image cache on a miss. This lets other
+// packages that index frames by their own convention, such as tiled resolving a tileset backed
+// by an Aseprite export, reuse this spritesheet's already-decoded image and cache instead of
+// opening and re-slicing the image themselves.
+func (spritesheet *AsespriteSpriteSheet) FrameImage(i int) (*ebiten.Image, error) {
+	return spritesheet.frameImage(i)
+}
+
+// frameImage returns the sub-image of the sprite sheet for the data.Frames entry at index i,
+// populating the image cache on a miss.
+func (spritesheet *AsespriteSpriteSheet) frameImage(i int) (*ebiten.Image, error) {
+	if i < 0 || i >= len(spritesheet.data.Frames) {
+		return nil, fmt.Errorf("asesprite: frame index %d is out of range (0-%d)", i, len(spritesheet.data.Frames)-1)
+	}
+
+	if img := spritesheet.imageCache[i]; img != nil {
+		return img, nil
+	}
+
+	f := spritesheet.data.Frames[i]
+
+	img, ok := spritesheet.image.SubImage(image.Rect(
+		f.Frame.X,
+		f.Frame.Y,
+		f.Frame.X+f.Frame.Width,
+		f.Frame.Y+f.Frame.Height)).(*ebiten.Image)
+
+	// As of Ebitengine 2.3.3, SubImage always returns *ebiten.Image.  This check
+	// is in place in case of future changes to this behavior, as well as changes
+	// to the image.Image interface.
+	if !ok {
+		return nil, errors.New("failed to cast image.Image to ebiten.Image")
+	}
+
+	spritesheet.imageCache[i] = img
+
+	return img, nil
+}
+
+// findFrameTag returns the frame tag with the matching name, or nil if none exists.
+func (spritesheet *AsespriteSpriteSheet) findFrameTag(name string) *frameTag {
+	for _, t := range spritesheet.data.Metadata.FrameTags {
+		if t.Name == name {
+			return &t
+		}
+	}
+	return nil
 }
 
 func (spritesheet *AsespriteSpriteSheet) Animation(name string) (*sprites.Animation, error) {
@@ -102,14 +359,7 @@ func (spritesheet *AsespriteSpriteSheet) Animation(name string) (*sprites.Animat
 	}
 
 	// Find the frame tag with the matching name.
-	var tag *frameTag
-
-	for _, t := range spritesheet.data.Metadata.FrameTags {
-		if t.Name == name {
-			tag = &t
-			break
-		}
-	}
+	tag := spritesheet.findFrameTag(name)
 
 	// Frame tag not found.
 	if tag == nil {
@@ -120,36 +370,61 @@ func (spritesheet *AsespriteSpriteSheet) Animation(name string) (*sprites.Animat
 	frames := make([]*sprites.Frame, 0, tag.To-tag.From+1)
 
 	for i := tag.From; i <= tag.To; i++ {
-		f := spritesheet.data.Frames[i]
+		img, err := spritesheet.frameImage(i)
+		if err != nil {
+			return nil, err
+		}
 
-		// Check image cache for existing image.
-		img := spritesheet.imageCache[i]
+		frames = append(frames, &sprites.Frame{Image: img, Duration: time.Duration(spritesheet.data.Frames[i].Duration) * time.Millisecond})
+	}
+	// Create the animation and add it to the animationCache.
+	animation = &sprites.Animation{Frames: frames, Direction: sprites.Direction(tag.Direction)}
+	spritesheet.animationCache[name] = animation
 
-		// Cache miss.
-		if img == nil {
+	return animation, nil
+}
 
-			img, ok = spritesheet.image.SubImage(image.Rect(
-				f.Frame.X,
-				f.Frame.Y,
-				f.Frame.X+f.Frame.Width,
-				f.Frame.Y+f.Frame.Height)).(*ebiten.Image)
+// AnimationRange returns a sub-animation spanning frames [from, to] of the named tag, addressed
+// relative to the tag's own frames (from=0 is the tag's first frame), inclusive on both ends.
+// This lets a single Aseprite tag be reused for several distinct clips, e.g. playing only the
+// "windup" portion of an "attack" tag before chaining into its "swing" portion.
+func (spritesheet *AsespriteSpriteSheet) AnimationRange(name string, from, to int) (*sprites.Animation, error) {
+	key := animationRangeKey{name: name, from: from, to: to}
 
-			// As of Ebitengine 2.3.3, SubImage always returns *ebiten.Image.  This check
-			// is in place in case of future changes to this behavior, as well as changes
-			// to the image.Image interface.
-			if !ok {
-				return nil, errors.New("failed to cast image.Image to ebiten.Image")
-			}
+	// First, check the animation range cache.
+	animation, ok := spritesheet.animationRangeCache[key]
+	if ok {
+		return animation, nil
+	}
+
+	// Find the frame tag with the matching name.
+	tag := spritesheet.findFrameTag(name)
 
-			// Add image to cache.
-			spritesheet.imageCache[i] = img
+	// Frame tag not found.
+	if tag == nil {
+		return nil, AnimationNotFoundError(name)
+	}
+
+	tagFrameCount := tag.To - tag.From + 1
+	if from < 0 || to < from || to >= tagFrameCount {
+		return nil, FrameRangeError(name, from, to)
+	}
+
+	// Create a slice of the frames.
+	frames := make([]*sprites.Frame, 0, to-from+1)
+
+	for i := tag.From + from; i <= tag.From+to; i++ {
+		img, err := spritesheet.frameImage(i)
+		if err != nil {
+			return nil, err
 		}
 
-		frames = append(frames, &sprites.Frame{Image: img, Duration: time.Millisecond * f.Duration})
+		frames = append(frames, &sprites.Frame{Image: img, Duration: time.Duration(spritesheet.data.Frames[i].Duration) * time.Millisecond})
 	}
-	// Create the animation and add it to the animationCache.
+
+	// Create the animation and add it to the animationRangeCache.
 	animation = &sprites.Animation{Frames: frames, Direction: sprites.Direction(tag.Direction)}
-	spritesheet.animationCache[name] = animation
+	spritesheet.animationRangeCache[key] = animation
 
 	return animation, nil
 }
@@ -170,27 +445,172 @@ func (s *AsespriteSpriteSheet) AllAnimations() (map[string]*sprites.Animation, e
 	return animations, nil
 }
 
-// NewSpritesheet returns an implementation of SpriteSheetLoader from an Asesprite JSON payload and a sprite sheet image.
-// Sprite sheet data must be in array-style format; hash format is unsupported at this time.
-func NewSpritesheet(decodedImage image.Image, jsonBytes []byte) (sprites.Spritesheet, error) {
-	var jsonData asespriteData
-	if errUnmarshal := json.Unmarshal(jsonBytes, &jsonData); errUnmarshal != nil {
-		return nil, fmt.Errorf("failed to unmarshal Asesprite JSON data: %w", errUnmarshal)
+// LayeredAnimation returns the tagged animation with the specified name, with its layers kept
+// separate instead of flattened, so a sprites.Sprite can composite and toggle them itself. The
+// sprite sheet must have been loaded with Options.SplitLayers set.
+func (spritesheet *AsespriteSpriteSheet) LayeredAnimation(name string) (*sprites.LayeredAnimation, error) {
+	// First, check the layered animation cache.
+	animation, ok := spritesheet.layeredAnimationCache[name]
+	if ok {
+		return animation, nil
+	}
+
+	if !spritesheet.options.SplitLayers {
+		return nil, errors.New("asesprite: sprite sheet was not loaded with Options.SplitLayers; layered animations are unavailable")
+	}
+
+	// Find the frame tag with the matching name.
+	tag := spritesheet.findFrameTag(name)
+
+	// Frame tag not found.
+	if tag == nil {
+		return nil, AnimationNotFoundError(name)
 	}
 
+	layers := make([]sprites.Layer, len(spritesheet.data.Metadata.Layers))
+	for i, l := range spritesheet.data.Metadata.Layers {
+		layers[i] = sprites.Layer{Name: l.Name, Opacity: l.Opacity, Blend: l.BlendMode.ebitenBlend()}
+	}
+
+	// Create a slice of the frames, each with one image per layer.
+	frames := make([]*sprites.LayeredFrame, 0, tag.To-tag.From+1)
+
+	for frameNumber := tag.From; frameNumber <= tag.To; frameNumber++ {
+		images := make([]*ebiten.Image, len(layers))
+		var duration time.Duration
+
+		for i, l := range spritesheet.data.Metadata.Layers {
+			dataIndex, ok := spritesheet.splitFrames[splitFrameKey{layer: l.Name, frameNumber: frameNumber}]
+			if !ok {
+				return nil, fmt.Errorf("asesprite: no exported frame found for layer '%s' at frame %d", l.Name, frameNumber)
+			}
+
+			img, err := spritesheet.frameImage(dataIndex)
+			if err != nil {
+				return nil, err
+			}
+
+			images[i] = img
+			duration = time.Duration(spritesheet.data.Frames[dataIndex].Duration) * time.Millisecond
+		}
+
+		frames = append(frames, &sprites.LayeredFrame{Images: images, Duration: duration})
+	}
+
+	// Create the animation and add it to the layeredAnimationCache.
+	animation = &sprites.LayeredAnimation{Layers: layers, Frames: frames, Direction: sprites.Direction(tag.Direction)}
+	spritesheet.layeredAnimationCache[name] = animation
+
+	return animation, nil
+}
+
+// DirectionalAnimation groups every frame tag named "<prefix>_<facing>" (e.g. "walk_n",
+// "walk_ne", following the convention used by Aseprite tag names for 8-way character
+// animations) into a sprites.DirectionalAnimation keyed by compass facing.
+func (spritesheet *AsespriteSpriteSheet) DirectionalAnimation(prefix string) (*sprites.DirectionalAnimation, error) {
+	directional := sprites.NewDirectionalAnimation()
+
+	for _, tag := range spritesheet.data.Metadata.FrameTags {
+		if !strings.HasPrefix(tag.Name, prefix+"_") {
+			continue
+		}
+
+		facing := sprites.Facing(tag.Name[len(prefix)+1:])
+		if !facing.Valid() {
+			continue
+		}
+
+		animation, err := spritesheet.Animation(tag.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		directional.Set(facing, animation)
+	}
+
+	if len(directional.Animations) == 0 {
+		return nil, fmt.Errorf("asesprite: no directional animations found with prefix '%s'", prefix)
+	}
+
+	return directional, nil
+}
+
+// Slice returns the named slice, such as a 9-patch panel or a pivot/hitbox region.
+func (spritesheet *AsespriteSpriteSheet) Slice(name string) (*Slice, error) {
+	// First, check the slice cache.
+	s, ok := spritesheet.sliceCache[name]
+	if ok {
+		return s, nil
+	}
+
+	for i := range spritesheet.data.Metadata.Slices {
+		if spritesheet.data.Metadata.Slices[i].Name == name {
+			s = &spritesheet.data.Metadata.Slices[i]
+			break
+		}
+	}
+
+	// Slice not found.
+	if s == nil {
+		return nil, SliceNotFoundError(name)
+	}
+
+	spritesheet.sliceCache[name] = s
+
+	return s, nil
+}
+
+// Slices returns a mapping of all of the sprite sheet's slices and their names.
+func (spritesheet *AsespriteSpriteSheet) Slices() (map[string]*Slice, error) {
+	slices := make(map[string]*Slice, len(spritesheet.data.Metadata.Slices))
+
+	for _, s := range spritesheet.data.Metadata.Slices {
+		slice, err := spritesheet.Slice(s.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		slices[s.Name] = slice
+	}
+
+	return slices, nil
+}
+
+// newSpritesheet assembles an AsespriteSpriteSheet from already-parsed data and an already
+// loaded image, applying options.
+func newSpritesheet(data *asespriteData, spritesheetImage *ebiten.Image, options Options) *AsespriteSpriteSheet {
 	spritesheet := &AsespriteSpriteSheet{
-		data:           &jsonData,
-		image:          ebiten.NewImageFromImage(decodedImage),
-		imageCache:     make([]*ebiten.Image, len(jsonData.Frames)),
-		animationCache: make(map[string]*sprites.Animation),
+		data:                  data,
+		image:                 spritesheetImage,
+		imageCache:            make([]*ebiten.Image, len(data.Frames)),
+		animationCache:        make(map[string]*sprites.Animation),
+		animationRangeCache:   make(map[animationRangeKey]*sprites.Animation),
+		sliceCache:            make(map[string]*Slice),
+		layeredAnimationCache: make(map[string]*sprites.LayeredAnimation),
+		options:               options,
+	}
+
+	if options.SplitLayers {
+		spritesheet.splitFrames = buildSplitFrames(data)
+	}
+
+	return spritesheet
+}
+
+// NewSpritesheet returns an implementation of Spritesheet from an Asesprite JSON payload and a sprite sheet image.
+// Sprite sheet data may be in either the array-style or hash-style `frames` format.
+func NewSpritesheet(decodedImage image.Image, jsonBytes []byte, options Options) (sprites.Spritesheet, error) {
+	var jsonData asespriteData
+	if errUnmarshal := json.Unmarshal(jsonBytes, &jsonData); errUnmarshal != nil {
+		return nil, fmt.Errorf("failed to unmarshal Asesprite JSON data: %w", errUnmarshal)
 	}
 
-	return spritesheet, nil
+	return newSpritesheet(&jsonData, ebiten.NewImageFromImage(decodedImage), options), nil
 }
 
-// NewSpritesheet returns an implementation of SpriteSheetLoader from disk.
-// Sprite sheet data must be in array-style format; hash format is unsupported at this time.
-func NewSpritesheetFromFiles(imagePath string, jsonPath string) (sprites.Spritesheet, error) {
+// NewSpritesheet returns an implementation of Spritesheet from disk.
+// Sprite sheet data may be in either the array-style or hash-style `frames` format.
+func NewSpritesheetFromFiles(imagePath string, jsonPath string, options Options) (sprites.Spritesheet, error) {
 	spritesheetImage, _, errOpenImage := ebitenutil.NewImageFromFile(imagePath)
 	if errOpenImage != nil {
 		return nil, fmt.Errorf(`failed to open image file: %w`, errOpenImage)
@@ -207,19 +627,12 @@ func NewSpritesheetFromFiles(imagePath string, jsonPath string) (sprites.Sprites
 		return nil, fmt.Errorf("failed to decode Asesprite JSON data: %w", errDecode)
 	}
 
-	spritesheet := &AsespriteSpriteSheet{
-		data:           &spritesheetData,
-		image:          spritesheetImage,
-		imageCache:     make([]*ebiten.Image, len(spritesheetData.Frames)),
-		animationCache: make(map[string]*sprites.Animation),
-	}
-
-	return spritesheet, nil
+	return newSpritesheet(&spritesheetData, spritesheetImage, options), nil
 }
 
-// NewSpritesheet returns an implementation of SpriteSheetLoader from a filesystem.
-// Sprite sheet data must be in array-style format; hash format is unsupported at this time.
-func NewSpritesheetFromFileSystem(filesystem fs.FS, imagePath string, jsonPath string) (sprites.Spritesheet, error) {
+// NewSpritesheet returns an implementation of Spritesheet from a filesystem.
+// Sprite sheet data may be in either the array-style or hash-style `frames` format.
+func NewSpritesheetFromFileSystem(filesystem fs.FS, imagePath string, jsonPath string, options Options) (sprites.Spritesheet, error) {
 	spritesheetImage, _, errOpenImage := ebitenutil.NewImageFromFileSystem(filesystem, imagePath)
 	if errOpenImage != nil {
 		return nil, fmt.Errorf(`failed to open image file: %w`, errOpenImage)
@@ -236,12 +649,5 @@ func NewSpritesheetFromFileSystem(filesystem fs.FS, imagePath string, jsonPath s
 		return nil, fmt.Errorf("failed to decode Asesprite JSON data: %w", errDecode)
 	}
 
-	spritesheet := &AsespriteSpriteSheet{
-		data:           &spritesheetData,
-		image:          spritesheetImage,
-		imageCache:     make([]*ebiten.Image, len(spritesheetData.Frames)),
-		animationCache: make(map[string]*sprites.Animation),
-	}
-
-	return spritesheet, nil
+	return newSpritesheet(&spritesheetData, spritesheetImage, options), nil
 }
@@ -7,3 +7,11 @@ import (
 func AnimationNotFoundError(name string) error {
 	return fmt.Errorf("no animation found with name '%s'", name)
 }
+
+func SliceNotFoundError(name string) error {
+	return fmt.Errorf("no slice found with name '%s'", name)
+}
+
+func FrameRangeError(name string, from, to int) error {
+	return fmt.Errorf("frame range %d-%d is not within animation '%s'", from, to, name)
+}
@@ -9,6 +9,21 @@ import (
 // Direction a possible animation direction (forward/reverse).
 type Direction string
 
+const (
+	// Forward plays frames from first to last, looping back to the first on repeat.
+	Forward Direction = "forward"
+
+	// Reverse plays frames from last to first, looping back to the last on repeat.
+	Reverse Direction = "reverse"
+
+	// PingPong plays forward to the last frame, then backward to the first, repeating that
+	// full back-and-forth cycle.
+	PingPong Direction = "pingpong"
+
+	// PingPongReverse is PingPong starting from the last frame and playing backward first.
+	PingPongReverse Direction = "pingpong_reverse"
+)
+
 // Frame represents a single animation frame.
 type Frame struct {
 	// Image is the frame's image.
@@ -28,3 +43,40 @@ type Animation struct {
 	// Direction is the direction the animation will be played (forward/reverse).
 	Direction Direction
 }
+
+// Layer describes one layer of a LayeredAnimation, as exported from a `--split-layers` sheet.
+type Layer struct {
+	// Name is the layer's name, as assigned in the source editor.
+	Name string
+
+	// Opacity is the layer's opacity, from 0 (fully transparent) to 255 (fully opaque).
+	Opacity uint8
+
+	// Blend is the layer's blend mode, mapped to the nearest equivalent ebiten.Blend.
+	Blend ebiten.Blend
+}
+
+// LayeredFrame represents a single frame of a LayeredAnimation, with one image per layer.
+type LayeredFrame struct {
+	// Images holds this frame's image for each of the LayeredAnimation's Layers, in the same
+	// order.
+	Images []*ebiten.Image
+
+	// Duration is the duration the frame is displayed before progressing to the next frame.
+	Duration time.Duration
+}
+
+// LayeredAnimation is an Animation whose frames have not been flattened, so that a Sprite can
+// composite its layers itself and toggle their visibility at runtime (e.g. paperdolling
+// equipment onto a character without re-exporting the sheet).
+type LayeredAnimation struct {
+	// Layers describes the layers present in every LayeredFrame, in draw order (bottom to
+	// top).
+	Layers []Layer
+
+	// Frames is a slice of this animation's individual frames.
+	Frames []*LayeredFrame
+
+	// Direction is the direction the animation will be played (forward/reverse).
+	Direction Direction
+}
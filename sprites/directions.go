@@ -0,0 +1,94 @@
+package sprites
+
+import "math"
+
+// Facing is an 8-way compass direction used to pick between a DirectionalAnimation's
+// per-direction Animations. Values match the suffix convention used for Aseprite tag names,
+// e.g. "walk_n", "walk_ne".
+type Facing string
+
+const (
+	North     Facing = "n"
+	Northeast Facing = "ne"
+	East      Facing = "e"
+	Southeast Facing = "se"
+	South     Facing = "s"
+	Southwest Facing = "sw"
+	West      Facing = "w"
+	Northwest Facing = "nw"
+)
+
+// facingAngles gives the canonical angle of each compass Facing, measured clockwise from East
+// (the same convention as math.Atan2(dy, dx) in a Y-down screen space, matching Sprite.Angle).
+var facingAngles = map[Facing]float64{
+	East:      0,
+	Southeast: math.Pi / 4,
+	South:     math.Pi / 2,
+	Southwest: 3 * math.Pi / 4,
+	West:      math.Pi,
+	Northwest: 5 * math.Pi / 4,
+	North:     3 * math.Pi / 2,
+	Northeast: 7 * math.Pi / 4,
+}
+
+// facingOrder lists every Facing in the same order as facingAngles, for deterministic
+// iteration (map iteration order is randomized).
+var facingOrder = []Facing{East, Southeast, South, Southwest, West, Northwest, North, Northeast}
+
+// Valid reports whether facing is one of the 8 known compass directions.
+func (facing Facing) Valid() bool {
+	_, ok := facingAngles[facing]
+	return ok
+}
+
+// DirectionalAnimation groups an Animation per compass Facing, e.g. so a character's walk
+// cycle can be addressed by both tag and facing.
+type DirectionalAnimation struct {
+	Animations map[Facing]*Animation
+}
+
+// NewDirectionalAnimation returns an empty DirectionalAnimation ready to have Animations
+// assigned via Set.
+func NewDirectionalAnimation() *DirectionalAnimation {
+	return &DirectionalAnimation{Animations: make(map[Facing]*Animation)}
+}
+
+// Set assigns the Animation to play when facing the given compass direction.
+func (directional *DirectionalAnimation) Set(facing Facing, animation *Animation) {
+	directional.Animations[facing] = animation
+}
+
+// nearestFacing returns the available Facing whose canonical angle is closest to
+// angleRadians, so that a direction missing from a partially-tagged DirectionalAnimation (e.g.
+// only the 4 cardinal directions) falls back to its closest neighbor instead of failing.
+func nearestFacing(angleRadians float64, available map[Facing]*Animation) (Facing, bool) {
+	if len(available) == 0 {
+		return "", false
+	}
+
+	normalized := math.Mod(angleRadians, 2*math.Pi)
+	if normalized < 0 {
+		normalized += 2 * math.Pi
+	}
+
+	best := Facing("")
+	bestDelta := math.Inf(1)
+
+	for _, facing := range facingOrder {
+		if _, ok := available[facing]; !ok {
+			continue
+		}
+
+		delta := math.Abs(normalized - facingAngles[facing])
+		if delta > math.Pi {
+			delta = 2*math.Pi - delta
+		}
+
+		if delta < bestDelta {
+			best = facing
+			bestDelta = delta
+		}
+	}
+
+	return best, best != ""
+}
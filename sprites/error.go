@@ -0,0 +1,15 @@
+package sprites
+
+import "fmt"
+
+// FrameRangeError reports that [from, to] is not a valid, in-bounds frame range for an
+// Animation with frameCount frames.
+func FrameRangeError(from, to, frameCount int) error {
+	return fmt.Errorf("frame range %d-%d is not within an animation of %d frames", from, to, frameCount)
+}
+
+// EmptyDirectionalAnimationError reports that a DirectionalAnimation has no Animations set, so
+// no facing could be resolved.
+func EmptyDirectionalAnimationError() error {
+	return fmt.Errorf("directional animation has no animations set")
+}
@@ -0,0 +1,36 @@
+package sprites
+
+import "time"
+
+// SpriteEventKind identifies what kind of transition a SpriteEvent reports.
+type SpriteEventKind int
+
+const (
+	// FrameEvent is emitted every time a Sprite's current frame changes.
+	FrameEvent SpriteEventKind = iota
+
+	// LoopEvent is emitted when a repeating animation finishes a cycle and starts over.
+	LoopEvent
+
+	// CompleteEvent is emitted when a non-repeating animation reaches its terminal frame and
+	// pauses on its own, as opposed to being paused by a call to Stop.
+	CompleteEvent
+)
+
+// SpriteEvent describes a single playback transition, for games that prefer polling
+// Sprite.Events over registering OnFrame/OnComplete/OnLoop callbacks.
+type SpriteEvent struct {
+	// Kind is the kind of transition that occurred.
+	Kind SpriteEventKind
+
+	// Frame is the frame the sprite was on when the event was emitted.
+	Frame int
+
+	// At is the time at which the transition was applied (the time.Time passed to Update that
+	// triggered it, not necessarily wall-clock "now").
+	At time.Time
+}
+
+// spriteEventBufferSize is the capacity of the channel returned by Sprite.Events. Once full,
+// further events are dropped rather than blocking Update.
+const spriteEventBufferSize = 32
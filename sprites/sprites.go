@@ -1,6 +1,7 @@
 package sprites
 
 import (
+	"image"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -79,16 +80,39 @@ type Sprite struct {
 	Y      float64
 	Origin Alignment
 	Scale  float64
-	Speed  float64
-	Angle  float64
+	// Speed is a playback rate multiplier: 2.0 plays twice as fast, 0.5 plays at half speed.
+	// Speed <= 0 pauses playback, same as Stop, without losing the sprite's current frame.
+	Speed float64
+	Angle float64
+
+	animation    *Animation
+	layered      *LayeredAnimation
+	layerVisible []bool
+	layerOptions []*ebiten.DrawImageOptions
+	directional  *DirectionalAnimation
+	facing       Facing
+	frame        int
+	step         int
+	traversals   int
+	paused       bool
+	Visible      bool
+	repeat       bool
+	last         time.Time
+	options      *ebiten.DrawImageOptions
+
+	frameCallbacks    map[int][]func(*Sprite)
+	completeCallbacks []func(*Sprite)
+	loopCallbacks     []func(*Sprite)
+	events            chan SpriteEvent
+
+	queue []queuedPlayback
+}
 
+// queuedPlayback describes one animation waiting in a Sprite's playback queue, populated by
+// Sprite.Queue and consumed by playNextQueued when the current animation completes.
+type queuedPlayback struct {
 	animation *Animation
-	frame     int
-	paused    bool
-	Visible   bool
 	repeat    bool
-	last      time.Time
-	options   *ebiten.DrawImageOptions
 }
 
 func NewSprite(animation *Animation) *Sprite {
@@ -102,7 +126,8 @@ func NewSprite(animation *Animation) *Sprite {
 		Visible: true,
 
 		animation: animation,
-		frame:     0,
+		frame:     initialFrame(animation.Direction, len(animation.Frames)),
+		step:      initialStep(animation.Direction),
 		paused:    false,
 		repeat:    true,
 		last:      time.Now(),
@@ -110,14 +135,196 @@ func NewSprite(animation *Animation) *Sprite {
 	}
 }
 
+// NewLayeredSprite creates a Sprite that plays back a LayeredAnimation, compositing its
+// layers on every Draw instead of drawing a single flattened image. All layers start visible;
+// use SetLayerVisible to toggle individual layers at runtime.
+func NewLayeredSprite(animation *LayeredAnimation) *Sprite {
+	return &Sprite{
+		X:       0.0,
+		Y:       0.0,
+		Origin:  TopLeft,
+		Scale:   1.0,
+		Speed:   1.0,
+		Angle:   0.0,
+		Visible: true,
+
+		layered:      animation,
+		layerVisible: newLayerVisibility(len(animation.Layers)),
+		layerOptions: newLayerOptions(len(animation.Layers)),
+		frame:        initialFrame(animation.Direction, len(animation.Frames)),
+		step:         initialStep(animation.Direction),
+		paused:       false,
+		repeat:       true,
+		last:         time.Now(),
+		options:      new(ebiten.DrawImageOptions),
+	}
+}
+
+// NewDirectionalSprite creates a Sprite that plays the Animation of directional facing nearest
+// to facing (falling back to the closest tagged direction if facing itself wasn't tagged, or to
+// East if facing isn't one of the 8 valid compass directions at all), and can be re-aimed at
+// runtime with SetFacing. It returns an error if directional has no Animations set.
+func NewDirectionalSprite(directional *DirectionalAnimation, facing Facing) (*Sprite, error) {
+	angle := 0.0 // East
+	if facing.Valid() {
+		angle = facingAngles[facing]
+	}
+
+	resolved, ok := nearestFacing(angle, directional.Animations)
+	if !ok {
+		return nil, EmptyDirectionalAnimationError()
+	}
+	animation := directional.Animations[resolved]
+
+	sprite := NewSprite(animation)
+	sprite.directional = directional
+	sprite.facing = resolved
+
+	return sprite, nil
+}
+
+// initialFrame returns the frame a freshly (re)started animation of the given direction should
+// begin on: the last frame for the reverse-starting directions, the first frame otherwise.
+func initialFrame(direction Direction, frameCount int) int {
+	switch direction {
+	case Reverse, PingPongReverse:
+		return frameCount - 1
+	default:
+		return 0
+	}
+}
+
+// initialStep returns the frame-index delta a freshly (re)started animation of the given
+// direction should begin stepping by.
+func initialStep(direction Direction) int {
+	switch direction {
+	case Reverse, PingPongReverse:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func newLayerVisibility(layerCount int) []bool {
+	visible := make([]bool, layerCount)
+	for i := range visible {
+		visible[i] = true
+	}
+	return visible
+}
+
+// newLayerOptions allocates one DrawImageOptions per layer, reused by drawLayered on every Draw
+// call instead of allocating fresh ones, matching how the flat-animation path reuses
+// Sprite.options.
+func newLayerOptions(layerCount int) []*ebiten.DrawImageOptions {
+	options := make([]*ebiten.DrawImageOptions, layerCount)
+	for i := range options {
+		options[i] = new(ebiten.DrawImageOptions)
+	}
+	return options
+}
+
 func (sprite *Sprite) SetAnimation(animation *Animation, repeat bool) {
 	sprite.animation = animation
-	sprite.frame = 0
+	sprite.layered = nil
+	sprite.directional = nil
+	sprite.frame = initialFrame(animation.Direction, len(animation.Frames))
+	sprite.step = initialStep(animation.Direction)
+	sprite.traversals = 0
 	sprite.paused = true
 	sprite.repeat = repeat
 	sprite.last = time.Now()
 }
 
+// PlayRange switches the sprite to play frames [from, to] of animation, inclusive, addressed
+// relative to animation's own frames. This lets a single Animation (e.g. one Aseprite tag) be
+// reused for several distinct clips, such as playing only the "windup" portion of an "attack"
+// animation before chaining into its "swing" portion with Queue.
+func (sprite *Sprite) PlayRange(animation *Animation, from, to int, repeat bool) error {
+	frameCount := len(animation.Frames)
+	if from < 0 || to < from || to >= frameCount {
+		return FrameRangeError(from, to, frameCount)
+	}
+
+	sprite.SetAnimation(&Animation{Frames: animation.Frames[from : to+1], Direction: animation.Direction}, repeat)
+
+	return nil
+}
+
+// Queue appends animation to the sprite's playback queue. Once the sprite's current animation
+// reaches its terminal frame and completes (see OnComplete), the next queued animation starts
+// playing automatically, continuing seamlessly instead of leaving the sprite paused. Queuing
+// behind a repeating animation has no effect until that animation stops completing on its own.
+func (sprite *Sprite) Queue(animation *Animation, repeat bool) {
+	sprite.queue = append(sprite.queue, queuedPlayback{animation: animation, repeat: repeat})
+}
+
+// SetLayeredAnimation switches the sprite to play back a LayeredAnimation, replacing any flat
+// Animation it was previously playing. All layers start visible.
+func (sprite *Sprite) SetLayeredAnimation(animation *LayeredAnimation, repeat bool) {
+	sprite.layered = animation
+	sprite.layerVisible = newLayerVisibility(len(animation.Layers))
+	sprite.layerOptions = newLayerOptions(len(animation.Layers))
+	sprite.animation = nil
+	sprite.directional = nil
+	sprite.frame = initialFrame(animation.Direction, len(animation.Frames))
+	sprite.step = initialStep(animation.Direction)
+	sprite.traversals = 0
+	sprite.paused = true
+	sprite.repeat = repeat
+	sprite.last = time.Now()
+}
+
+// SetFacing snaps the sprite to the available direction of its DirectionalAnimation nearest to
+// angleRadians and switches to that direction's Animation, without resetting the frame index or
+// playback state — so turning mid-stride doesn't visibly restart the animation. It is a no-op
+// if the sprite isn't playing a DirectionalAnimation. If the new facing's Animation has a
+// different Direction than the one being left (e.g. a ping-pong idle mixed with forward-only
+// walk tags), the step sign and traversal count are reset for that new Direction, since a stale
+// step/traversals from the old Direction could otherwise step the new animation backward or end
+// it early.
+func (sprite *Sprite) SetFacing(angleRadians float64) {
+	if sprite.directional == nil {
+		return
+	}
+
+	facing, ok := nearestFacing(angleRadians, sprite.directional.Animations)
+	if !ok || facing == sprite.facing {
+		return
+	}
+
+	animation := sprite.directional.Animations[facing]
+	previousDirection := sprite.animation.Direction
+
+	sprite.facing = facing
+	sprite.animation = animation
+
+	if sprite.frame >= len(animation.Frames) {
+		sprite.frame = len(animation.Frames) - 1
+	}
+
+	if animation.Direction != previousDirection {
+		sprite.step = initialStep(animation.Direction)
+		sprite.traversals = 0
+	}
+}
+
+// SetLayerVisible shows or hides the named layer of the sprite's current LayeredAnimation,
+// without affecting playback. It is a no-op if the sprite isn't playing a LayeredAnimation or
+// the layer name doesn't exist, e.g. games toggling equipment/paperdoll layers by name.
+func (sprite *Sprite) SetLayerVisible(name string, visible bool) {
+	if sprite.layered == nil {
+		return
+	}
+
+	for i, layer := range sprite.layered.Layers {
+		if layer.Name == name {
+			sprite.layerVisible[i] = visible
+			return
+		}
+	}
+}
+
 func (sprite *Sprite) Start(at time.Time) {
 	if !sprite.paused {
 		return
@@ -127,7 +334,9 @@ func (sprite *Sprite) Start(at time.Time) {
 		at = time.Now()
 	}
 
-	sprite.frame = 0
+	sprite.frame = initialFrame(sprite.direction(), sprite.frameCount())
+	sprite.step = initialStep(sprite.direction())
+	sprite.traversals = 0
 	sprite.last = at
 	sprite.paused = false
 }
@@ -137,32 +346,226 @@ func (sprite *Sprite) Stop() {
 		return
 	}
 
-	sprite.frame = 0
+	sprite.frame = initialFrame(sprite.direction(), sprite.frameCount())
+	sprite.step = initialStep(sprite.direction())
+	sprite.traversals = 0
 	sprite.last = time.Now()
 	sprite.paused = true
 }
 
+// CurrentFrame returns the Frame currently displayed by the sprite's flat Animation. It is nil
+// if the sprite is playing a LayeredAnimation instead, for which Draw composites every layer's
+// own current frame rather than a single Frame.
+func (sprite *Sprite) CurrentFrame() *Frame {
+	if sprite.animation == nil {
+		return nil
+	}
+	return sprite.animation.Frames[sprite.frame]
+}
+
+// direction returns the playback direction of whichever animation the sprite is playing.
+func (sprite *Sprite) direction() Direction {
+	if sprite.layered != nil {
+		return sprite.layered.Direction
+	}
+	return sprite.animation.Direction
+}
+
+// frameCount returns the number of frames in whichever animation the sprite is playing.
+func (sprite *Sprite) frameCount() int {
+	if sprite.layered != nil {
+		return len(sprite.layered.Frames)
+	}
+	return len(sprite.animation.Frames)
+}
+
+// frameDuration returns the current frame's duration in whichever animation the sprite is
+// playing.
+func (sprite *Sprite) frameDuration() time.Duration {
+	if sprite.layered != nil {
+		return sprite.layered.Frames[sprite.frame].Duration
+	}
+	return sprite.animation.Frames[sprite.frame].Duration
+}
+
+// OnFrame registers fn to be called every time the sprite's current frame becomes index, e.g.
+// to trigger a footstep sound or an attack's hit check on a specific frame.
+func (sprite *Sprite) OnFrame(index int, fn func(*Sprite)) {
+	if sprite.frameCallbacks == nil {
+		sprite.frameCallbacks = make(map[int][]func(*Sprite))
+	}
+	sprite.frameCallbacks[index] = append(sprite.frameCallbacks[index], fn)
+}
+
+// OnComplete registers fn to be called when a non-repeating animation reaches its terminal
+// frame and pauses on its own.
+func (sprite *Sprite) OnComplete(fn func(*Sprite)) {
+	sprite.completeCallbacks = append(sprite.completeCallbacks, fn)
+}
+
+// OnLoop registers fn to be called every time a repeating animation finishes a cycle and
+// starts over.
+func (sprite *Sprite) OnLoop(fn func(*Sprite)) {
+	sprite.loopCallbacks = append(sprite.loopCallbacks, fn)
+}
+
+// Events returns a buffered channel of the sprite's playback transitions, for games that would
+// rather poll from their game loop than register callbacks. The channel is created on first
+// call and reused afterward; once its buffer is full, further events are dropped rather than
+// blocking Update.
+func (sprite *Sprite) Events() <-chan SpriteEvent {
+	if sprite.events == nil {
+		sprite.events = make(chan SpriteEvent, spriteEventBufferSize)
+	}
+	return sprite.events
+}
+
+func (sprite *Sprite) emit(event SpriteEvent) {
+	if sprite.events == nil {
+		return
+	}
+
+	select {
+	case sprite.events <- event:
+	default:
+	}
+}
+
+func (sprite *Sprite) fireFrame(at time.Time) {
+	for _, fn := range sprite.frameCallbacks[sprite.frame] {
+		fn(sprite)
+	}
+	sprite.emit(SpriteEvent{Kind: FrameEvent, Frame: sprite.frame, At: at})
+}
+
+func (sprite *Sprite) fireLoop(at time.Time) {
+	for _, fn := range sprite.loopCallbacks {
+		fn(sprite)
+	}
+	sprite.emit(SpriteEvent{Kind: LoopEvent, Frame: sprite.frame, At: at})
+}
+
+func (sprite *Sprite) fireComplete(at time.Time) {
+	for _, fn := range sprite.completeCallbacks {
+		fn(sprite)
+	}
+	sprite.emit(SpriteEvent{Kind: CompleteEvent, Frame: sprite.frame, At: at})
+}
+
+// Update advances the sprite's playback to reflect the time elapsed since its last Update (or
+// since it was started/stopped). It consumes elapsed time one frame Duration at a time, so a
+// large elapsed delta (e.g. after a stutter) correctly steps through every intervening frame,
+// firing each one's callbacks/events exactly once, rather than skipping straight to wherever a
+// single step would land.
 func (sprite *Sprite) Update(at time.Time) {
 	if sprite.paused {
 		return
 	}
 
-	elapsed := at.Sub(sprite.last)
-	duration := sprite.animation.Frames[sprite.frame].Duration * time.Duration(sprite.Speed)
-	if elapsed < duration {
+	if sprite.Speed <= 0 {
 		return
 	}
 
-	if sprite.frame >= len(sprite.animation.Frames)-1 {
-		if sprite.repeat {
+	elapsed := at.Sub(sprite.last)
+
+	// maxAdvancesPerUpdate bounds how many frames a single Update call will step through. A
+	// frame with a zero (or negative, post-Speed) duration must still advance rather than stall
+	// forever, but without a bound an animation made entirely of zero-duration frames would spin
+	// this loop indefinitely.
+	const maxAdvancesPerUpdate = 1000
+
+	for i := 0; !sprite.paused && i < maxAdvancesPerUpdate; i++ {
+		duration := time.Duration(float64(sprite.frameDuration()) / sprite.Speed)
+		if duration > 0 {
+			if elapsed < duration {
+				break
+			}
+			elapsed -= duration
+		}
+
+		sprite.last = sprite.last.Add(duration)
+		sprite.advance(sprite.last)
+	}
+}
+
+// advance steps the sprite to its next frame according to the active animation's Direction.
+// Forward and Reverse step monotonically with wraparound; PingPong and PingPongReverse flip
+// sprite.step at each endpoint and count a full cycle as two traversals (once forward, once
+// back) so that repeat=false stops at the correct terminal frame rather than mid-bounce.
+func (sprite *Sprite) advance(at time.Time) {
+	count := sprite.frameCount()
+	sprite.frame += sprite.step
+
+	looped := false
+	completed := false
+
+	switch sprite.direction() {
+	case PingPong, PingPongReverse:
+		if sprite.frame >= count-1 {
+			sprite.frame = count - 1
+			sprite.step = -1
+			sprite.traversals++
+		} else if sprite.frame <= 0 {
 			sprite.frame = 0
-		} else {
-			sprite.paused = true
+			sprite.step = 1
+			sprite.traversals++
 		}
-	} else {
-		sprite.frame++
+
+		if sprite.traversals >= 2 {
+			if sprite.repeat {
+				sprite.traversals = 0
+				looped = true
+			} else {
+				sprite.paused = true
+				completed = true
+			}
+		}
+
+	default: // Forward, Reverse
+		if sprite.frame >= count {
+			if sprite.repeat {
+				sprite.frame = 0
+				looped = true
+			} else {
+				sprite.frame = count - 1
+				sprite.paused = true
+				completed = true
+			}
+		} else if sprite.frame < 0 {
+			if sprite.repeat {
+				sprite.frame = count - 1
+				looped = true
+			} else {
+				sprite.frame = 0
+				sprite.paused = true
+				completed = true
+			}
+		}
+	}
+
+	sprite.fireFrame(at)
+	if looped {
+		sprite.fireLoop(at)
+	}
+	if completed {
+		sprite.fireComplete(at)
+		sprite.playNextQueued(at)
+	}
+}
+
+// playNextQueued starts the next animation waiting in the sprite's playback queue, if any,
+// continuing playback seamlessly instead of leaving the sprite paused on its last frame.
+func (sprite *Sprite) playNextQueued(at time.Time) {
+	if len(sprite.queue) == 0 {
+		return
 	}
+
+	next := sprite.queue[0]
+	sprite.queue = sprite.queue[1:]
+
+	sprite.SetAnimation(next.animation, next.repeat)
 	sprite.last = at
+	sprite.paused = false
 }
 
 func (sprite *Sprite) Draw(target *ebiten.Image) {
@@ -170,6 +573,11 @@ func (sprite *Sprite) Draw(target *ebiten.Image) {
 		return
 	}
 
+	if sprite.layered != nil {
+		sprite.drawLayered(target)
+		return
+	}
+
 	frame := sprite.animation.Frames[sprite.frame]
 	bounds := frame.Image.Bounds()
 	width, height := bounds.Dx(), bounds.Dy()
@@ -184,3 +592,88 @@ func (sprite *Sprite) Draw(target *ebiten.Image) {
 
 	target.DrawImage(frame.Image, sprite.options)
 }
+
+// drawLayered draws every visible layer of the current LayeredAnimation frame, bottom to top,
+// applying each layer's opacity and blend mode.
+func (sprite *Sprite) drawLayered(target *ebiten.Image) {
+	frame := sprite.layered.Frames[sprite.frame]
+
+	for i, layer := range sprite.layered.Layers {
+		if !sprite.layerVisible[i] {
+			continue
+		}
+
+		img := frame.Images[i]
+		if img == nil {
+			continue
+		}
+
+		bounds := img.Bounds()
+		drawWidth := float64(bounds.Dx()) * sprite.Scale
+		drawHeight := float64(bounds.Dy()) * sprite.Scale
+		drawX, drawY := sprite.Origin(sprite.X, sprite.Y, drawWidth, drawHeight)
+
+		options := sprite.layerOptions[i]
+		options.GeoM.Reset()
+		options.GeoM.Scale(sprite.Scale, sprite.Scale)
+		options.GeoM.Translate(drawX, drawY)
+		options.GeoM.Rotate(sprite.Angle)
+		options.ColorScale.Reset()
+		options.ColorScale.ScaleAlpha(float32(layer.Opacity) / 255)
+		options.Blend = layer.Blend
+
+		target.DrawImage(img, options)
+	}
+}
+
+// NinePatch draws the sprite's current frame onto target, stretched to fill bounds, using a
+// 9-slice grid: the four corners are drawn at their original size, the four edges are
+// stretched along one axis, and the middle band is stretched along both. center is the
+// stretchy middle band, given in the frame image's own local coordinates (i.e. relative to
+// the frame's top-left corner, not the sprite sheet).
+func (sprite *Sprite) NinePatch(target *ebiten.Image, bounds image.Rectangle, center image.Rectangle) {
+	if !sprite.Visible || sprite.animation == nil {
+		return
+	}
+
+	frame := sprite.animation.Frames[sprite.frame]
+	frameBounds := frame.Image.Bounds()
+
+	srcX := [4]int{0, center.Min.X, center.Max.X, frameBounds.Dx()}
+	srcY := [4]int{0, center.Min.Y, center.Max.Y, frameBounds.Dy()}
+
+	destWidth := [3]int{srcX[1] - srcX[0], 0, srcX[3] - srcX[2]}
+	if destWidth[1] = bounds.Dx() - destWidth[0] - destWidth[2]; destWidth[1] < 0 {
+		destWidth[1] = 0
+	}
+
+	destHeight := [3]int{srcY[1] - srcY[0], 0, srcY[3] - srcY[2]}
+	if destHeight[1] = bounds.Dy() - destHeight[0] - destHeight[2]; destHeight[1] < 0 {
+		destHeight[1] = 0
+	}
+
+	destOffsetX := [4]int{0, destWidth[0], destWidth[0] + destWidth[1], destWidth[0] + destWidth[1] + destWidth[2]}
+	destOffsetY := [4]int{0, destHeight[0], destHeight[0] + destHeight[1], destHeight[0] + destHeight[1] + destHeight[2]}
+
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			srcRect := image.Rect(
+				frameBounds.Min.X+srcX[col], frameBounds.Min.Y+srcY[row],
+				frameBounds.Min.X+srcX[col+1], frameBounds.Min.Y+srcY[row+1])
+			if srcRect.Dx() <= 0 || srcRect.Dy() <= 0 {
+				continue
+			}
+
+			cell, ok := frame.Image.SubImage(srcRect).(*ebiten.Image)
+			if !ok {
+				continue
+			}
+
+			options := new(ebiten.DrawImageOptions)
+			options.GeoM.Scale(float64(destWidth[col])/float64(srcRect.Dx()), float64(destHeight[row])/float64(srcRect.Dy()))
+			options.GeoM.Translate(float64(bounds.Min.X+destOffsetX[col]), float64(bounds.Min.Y+destOffsetY[row]))
+
+			target.DrawImage(cell, options)
+		}
+	}
+}
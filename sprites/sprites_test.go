@@ -0,0 +1,116 @@
+package sprites
+
+import (
+	"testing"
+	"time"
+)
+
+// framesOfDuration builds n frames, each lasting duration, with no Image (these tests never
+// Draw, so a real *ebiten.Image is never needed).
+func framesOfDuration(n int, duration time.Duration) []*Frame {
+	frames := make([]*Frame, n)
+	for i := range frames {
+		frames[i] = &Frame{Duration: duration}
+	}
+	return frames
+}
+
+func TestUpdateAdvancesOneFramePerDuration(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(4, 100*time.Millisecond), Direction: Forward}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+
+	start := sprite.last
+	sprite.Update(start.Add(250 * time.Millisecond))
+
+	if sprite.frame != 2 {
+		t.Fatalf("frame = %d, want 2 (250ms / 100ms/frame, truncated)", sprite.frame)
+	}
+
+	// The 50ms remainder should be carried forward, not dropped: one more 100ms tick should
+	// advance exactly one more frame.
+	sprite.Update(sprite.last.Add(100 * time.Millisecond))
+	if sprite.frame != 3 {
+		t.Fatalf("frame = %d, want 3 after one more full frame duration", sprite.frame)
+	}
+}
+
+func TestUpdateSpeedScalesFrameDuration(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(4, 100*time.Millisecond), Direction: Forward}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+	sprite.Speed = 2.0
+
+	// At 2x speed, a 100ms frame effectively lasts 50ms, so 100ms elapsed should advance two
+	// frames, not one.
+	sprite.Update(sprite.last.Add(100 * time.Millisecond))
+
+	if sprite.frame != 2 {
+		t.Fatalf("frame = %d, want 2 at 2x speed", sprite.frame)
+	}
+}
+
+func TestUpdateZeroDurationFrameStillAdvances(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(5, 0), Direction: Forward}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+
+	// Zero-duration frames must still advance rather than stall forever; with repeat=true this
+	// runs until maxAdvancesPerUpdate, landing back on frame 0 after a multiple of 5 steps.
+	sprite.Update(sprite.last)
+
+	if sprite.paused {
+		t.Fatalf("sprite paused on zero-duration frames, want it to keep advancing")
+	}
+	if sprite.frame != 0 {
+		t.Fatalf("frame = %d, want 0 (1000 advances over a 5-frame loop returns to the start)", sprite.frame)
+	}
+}
+
+func TestUpdatePausedOrStoppedSpeedIsNoOp(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(4, 100*time.Millisecond), Direction: Forward}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+	sprite.Speed = 0
+
+	sprite.Update(sprite.last.Add(time.Second))
+	if sprite.frame != 0 {
+		t.Fatalf("frame = %d, want 0 (Speed <= 0 must pause playback without losing position)", sprite.frame)
+	}
+}
+
+func TestAdvancePingPongCountsTwoTraversalsPerCycle(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(3, time.Millisecond), Direction: PingPong}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+	sprite.repeat = false
+
+	// Frames: 0 -> 1 -> 2 (first traversal, reverses) -> 1 -> 0 (second traversal, completes).
+	for i := 0; i < 4; i++ {
+		sprite.advance(time.Time{})
+	}
+
+	if !sprite.paused {
+		t.Fatalf("sprite not paused after two ping-pong traversals with repeat=false")
+	}
+	if sprite.frame != 0 {
+		t.Fatalf("frame = %d, want 0 (terminal frame of a non-repeating ping-pong)", sprite.frame)
+	}
+}
+
+func TestAdvancePingPongLoopsWhenRepeating(t *testing.T) {
+	animation := &Animation{Frames: framesOfDuration(3, time.Millisecond), Direction: PingPong}
+	sprite := NewSprite(animation)
+	sprite.Start(time.Time{})
+
+	for i := 0; i < 4; i++ {
+		sprite.advance(time.Time{})
+	}
+
+	if sprite.paused {
+		t.Fatalf("repeating ping-pong animation paused after completing a cycle")
+	}
+	if sprite.traversals != 0 {
+		t.Fatalf("traversals = %d, want 0 (reset at the end of a completed cycle)", sprite.traversals)
+	}
+}
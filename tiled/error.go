@@ -0,0 +1,7 @@
+package tiled
+
+import "fmt"
+
+func LayerNotFoundError(name string) error {
+	return fmt.Errorf("no layer found with name '%s'", name)
+}
@@ -0,0 +1,515 @@
+// Package tiled loads maps exported from the Tiled map editor in its JSON map/tileset format,
+// for use alongside sprites.Sprite-based characters and props.
+package tiled
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"io/fs"
+	"math"
+	"path"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/sorucoder/ebiten-sprite/asesprite"
+	"github.com/sorucoder/ebiten-sprite/sprites"
+)
+
+// The three high bits Tiled packs into every non-zero GID to record how the referenced tile is
+// flipped, independent of its base tile id.
+const (
+	flippedHorizontallyFlag uint32 = 0x80000000
+	flippedVerticallyFlag   uint32 = 0x40000000
+	flippedDiagonallyFlag   uint32 = 0x20000000
+	gidFlipMask             uint32 = flippedHorizontallyFlag | flippedVerticallyFlag | flippedDiagonallyFlag
+)
+
+// The three flip flags reduced to a 3-bit index into resolvedTileset.flipGeoMs, precomputed
+// once per tileset at load time instead of being rebuilt on every draw call.
+const (
+	flipBitHorizontal = 1 << iota
+	flipBitVertical
+	flipBitDiagonal
+)
+
+// Camera describes the view used to draw a Map's layers: the world-space point shown at the
+// draw target's top-left corner, plus a uniform zoom factor. A zero Camera draws the map at
+// (0, 0) and 1x zoom.
+type Camera struct {
+	X, Y float64
+	Zoom float64
+}
+
+// Options configures how LoadMap resolves a map's tilesets.
+type Options struct {
+	// Spritesheets maps a tileset's `image` path, exactly as it appears in the Tiled tileset
+	// JSON, to an already-loaded Aseprite spritesheet whose frames should be reused instead of
+	// opening and re-slicing the tileset image from disk. This is for tilesets exported from the
+	// same Aseprite sheet a character's animations already come from, so the two share one
+	// decoded image and frame cache rather than loading the same pixels twice. The Aseprite
+	// sheet's raw frames (AsespriteSpriteSheet.FrameImage) are addressed by the same local tile
+	// id Tiled assigns, which holds as long as both were exported from the same source in frame
+	// order.
+	Spritesheets map[string]*asesprite.AsespriteSpriteSheet
+}
+
+// frameData is one entry of a Tiled tile's `animation` array.
+type frameData struct {
+	TileID   int `json:"tileid"`
+	Duration int `json:"duration"` // milliseconds
+}
+
+// tileData describes the per-tile metadata Tiled records for a tileset's `tiles` array. Only
+// animation frames are currently used.
+type tileData struct {
+	ID        int         `json:"id"`
+	Animation []frameData `json:"animation"`
+}
+
+// tilesetData is the shape shared by an embedded tileset entry and an external `.tsj` tileset
+// file.
+type tilesetData struct {
+	Image       string     `json:"image"`
+	ImageWidth  int        `json:"imagewidth"`
+	ImageHeight int        `json:"imageheight"`
+	TileWidth   int        `json:"tilewidth"`
+	TileHeight  int        `json:"tileheight"`
+	Margin      int        `json:"margin"`
+	Spacing     int        `json:"spacing"`
+	Columns     int        `json:"columns"`
+	TileCount   int        `json:"tilecount"`
+	Tiles       []tileData `json:"tiles"`
+}
+
+// tilesetRef is one entry of a Tiled map's `tilesets` array: a firstgid paired with either an
+// embedded tilesetData or a `source` pointing at an external `.tsj` file.
+type tilesetRef struct {
+	FirstGID int    `json:"firstgid"`
+	Source   string `json:"source"`
+	tilesetData
+}
+
+// layerData is one entry of a Tiled map's `layers` array. Only tile layers are currently
+// supported; other layer types (object groups, image layers, groups) are skipped.
+type layerData struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Width   int      `json:"width"`
+	Height  int      `json:"height"`
+	Visible bool     `json:"visible"`
+	Opacity float64  `json:"opacity"`
+	Data    []uint32 `json:"data"`
+}
+
+// mapData is the top-level shape of a Tiled JSON map file.
+type mapData struct {
+	Width      int          `json:"width"`
+	Height     int          `json:"height"`
+	TileWidth  int          `json:"tilewidth"`
+	TileHeight int          `json:"tileheight"`
+	Layers     []layerData  `json:"layers"`
+	Tilesets   []tilesetRef `json:"tilesets"`
+}
+
+// Layer is one tile layer of a loaded Map.
+type Layer struct {
+	Name    string
+	Width   int
+	Height  int
+	Visible bool
+	Opacity float64
+
+	gids []uint32
+}
+
+// resolvedTileset is a tileset whose image has been loaded (or handed off to an existing
+// AsespriteSpriteSheet) and whose GID range has been resolved relative to the Map it belongs to.
+type resolvedTileset struct {
+	firstGID   uint32
+	tileCount  int
+	columns    int
+	margin     int
+	spacing    int
+	tileWidth  int
+	tileHeight int
+
+	image      *ebiten.Image
+	imageCache []*ebiten.Image
+
+	// spritesheet is set instead of image/imageCache when this tileset was resolved from
+	// Options.Spritesheets, so its frames (and their cache) belong to an AsespriteSpriteSheet
+	// the caller already loaded.
+	spritesheet *asesprite.AsespriteSpriteSheet
+
+	animations map[int][]frameData // local tile id -> animation frames
+	flipGeoMs  [8]ebiten.GeoM      // indexed by flipBitHorizontal|flipBitVertical|flipBitDiagonal
+}
+
+// tileImage returns the image for the tile at localID, populating the image cache on a miss (or
+// delegating to spritesheet's own cache, when set).
+func (tileset *resolvedTileset) tileImage(localID int) (*ebiten.Image, error) {
+	if localID < 0 || localID >= tileset.tileCount {
+		return nil, fmt.Errorf("tiled: local tile id %d is out of range (0-%d)", localID, tileset.tileCount-1)
+	}
+
+	if tileset.spritesheet != nil {
+		return tileset.spritesheet.FrameImage(localID)
+	}
+
+	if img := tileset.imageCache[localID]; img != nil {
+		return img, nil
+	}
+
+	column := localID % tileset.columns
+	row := localID / tileset.columns
+
+	x := tileset.margin + column*(tileset.tileWidth+tileset.spacing)
+	y := tileset.margin + row*(tileset.tileHeight+tileset.spacing)
+
+	img := tileset.image.SubImage(image.Rect(x, y, x+tileset.tileWidth, y+tileset.tileHeight)).(*ebiten.Image)
+	tileset.imageCache[localID] = img
+
+	return img, nil
+}
+
+// flipGeoM returns the precomputed in-place flip transform for the given combination of Tiled
+// GID flip flags.
+func (tileset *resolvedTileset) flipGeoM(flipHorizontal, flipVertical, flipDiagonal bool) ebiten.GeoM {
+	index := 0
+	if flipHorizontal {
+		index |= flipBitHorizontal
+	}
+	if flipVertical {
+		index |= flipBitVertical
+	}
+	if flipDiagonal {
+		index |= flipBitDiagonal
+	}
+	return tileset.flipGeoMs[index]
+}
+
+// precomputeFlipGeoMs fills in flipGeoMs for all 8 combinations of the 3 flip flags, once per
+// tileset at load time, so DrawLayer never rebuilds a flip transform while drawing.
+func (tileset *resolvedTileset) precomputeFlipGeoMs() {
+	for index := 0; index < len(tileset.flipGeoMs); index++ {
+		tileset.flipGeoMs[index] = tileGeoM(
+			index&flipBitHorizontal != 0,
+			index&flipBitVertical != 0,
+			index&flipBitDiagonal != 0,
+			float64(tileset.tileWidth),
+			float64(tileset.tileHeight),
+		)
+	}
+}
+
+// animatedTileKey identifies one animated tile definition across a Map's resolved tilesets.
+type animatedTileKey struct {
+	tileset int
+	localID int
+}
+
+// Map is a loaded Tiled map: its tile layers, resolved tilesets, and the running animations of
+// any animated tiles they reference.
+type Map struct {
+	Width      int
+	Height     int
+	TileWidth  int
+	TileHeight int
+
+	layers   []*Layer
+	tilesets []*resolvedTileset
+	animated map[animatedTileKey]*sprites.Sprite
+}
+
+// LoadMap parses the Tiled JSON map file at path within filesystem, resolving any externally
+// referenced `.tsj` tilesets relative to the files that reference them, and starts playback of
+// every animated tile's sprites.Animation.
+func LoadMap(filesystem fs.FS, mapPath string, options Options) (*Map, error) {
+	var data mapData
+	if err := decodeJSONFile(filesystem, mapPath, &data); err != nil {
+		return nil, fmt.Errorf("tiled: failed to decode map file '%s': %w", mapPath, err)
+	}
+
+	m := &Map{
+		Width:      data.Width,
+		Height:     data.Height,
+		TileWidth:  data.TileWidth,
+		TileHeight: data.TileHeight,
+		animated:   make(map[animatedTileKey]*sprites.Sprite),
+	}
+
+	mapDir := path.Dir(mapPath)
+
+	for _, ref := range data.Tilesets {
+		tileset := ref.tilesetData
+		tilesetDir := mapDir
+
+		if ref.Source != "" {
+			tilesetPath := path.Join(mapDir, ref.Source)
+			if err := decodeJSONFile(filesystem, tilesetPath, &tileset); err != nil {
+				return nil, fmt.Errorf("tiled: failed to decode tileset file '%s': %w", tilesetPath, err)
+			}
+			tilesetDir = path.Dir(tilesetPath)
+		}
+
+		resolved, err := resolveTileset(filesystem, tilesetDir, ref.FirstGID, tileset, options)
+		if err != nil {
+			return nil, err
+		}
+
+		m.tilesets = append(m.tilesets, resolved)
+	}
+
+	for _, ld := range data.Layers {
+		if ld.Type != "tilelayer" {
+			continue
+		}
+
+		m.layers = append(m.layers, &Layer{
+			Name:    ld.Name,
+			Width:   ld.Width,
+			Height:  ld.Height,
+			Visible: ld.Visible,
+			Opacity: ld.Opacity,
+			gids:    ld.Data,
+		})
+	}
+
+	if err := m.startAnimations(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// decodeJSONFile opens and JSON-decodes the file at path within filesystem into v.
+func decodeJSONFile(filesystem fs.FS, path string, v any) error {
+	file, err := filesystem.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return json.NewDecoder(file).Decode(v)
+}
+
+// resolveTileset loads a tileset's image (or adopts an already-loaded AsespriteSpriteSheet from
+// options.Spritesheets) and indexes its animated tiles, resolving image paths relative to dir
+// (the directory of whichever file declared the tileset).
+func resolveTileset(filesystem fs.FS, dir string, firstGID int, data tilesetData, options Options) (*resolvedTileset, error) {
+	tileset := &resolvedTileset{
+		firstGID:   uint32(firstGID),
+		tileCount:  data.TileCount,
+		margin:     data.Margin,
+		spacing:    data.Spacing,
+		tileWidth:  data.TileWidth,
+		tileHeight: data.TileHeight,
+		animations: make(map[int][]frameData),
+	}
+
+	for _, tile := range data.Tiles {
+		if len(tile.Animation) > 0 {
+			tileset.animations[tile.ID] = tile.Animation
+		}
+	}
+
+	if sheet, ok := options.Spritesheets[data.Image]; ok {
+		tileset.spritesheet = sheet
+		if tileset.tileCount == 0 {
+			tileset.tileCount = sheet.FrameCount()
+		}
+	} else {
+		tilesetImage, _, err := ebitenutil.NewImageFromFileSystem(filesystem, path.Join(dir, data.Image))
+		if err != nil {
+			return nil, fmt.Errorf("tiled: failed to open tileset image '%s': %w", data.Image, err)
+		}
+
+		columns := data.Columns
+		if columns == 0 {
+			columns = (data.ImageWidth - 2*data.Margin + data.Spacing) / (data.TileWidth + data.Spacing)
+		}
+
+		tileset.image = tilesetImage
+		tileset.columns = columns
+		tileset.imageCache = make([]*ebiten.Image, data.TileCount)
+	}
+
+	tileset.precomputeFlipGeoMs()
+
+	return tileset, nil
+}
+
+// startAnimations builds a sprites.Animation and a playing sprites.Sprite for every animated
+// tile across the map's resolved tilesets, reusing the same playback machinery a character
+// sprite uses.
+func (m *Map) startAnimations() error {
+	for tilesetIndex, tileset := range m.tilesets {
+		for localID, frames := range tileset.animations {
+			frameList := make([]*sprites.Frame, len(frames))
+			for i, f := range frames {
+				img, err := tileset.tileImage(f.TileID)
+				if err != nil {
+					return err
+				}
+
+				frameList[i] = &sprites.Frame{Image: img, Duration: time.Duration(f.Duration) * time.Millisecond}
+			}
+
+			animation := &sprites.Animation{Frames: frameList, Direction: sprites.Forward}
+			m.animated[animatedTileKey{tileset: tilesetIndex, localID: localID}] = sprites.NewSprite(animation)
+		}
+	}
+
+	return nil
+}
+
+// Update advances every animated tile's playback to reflect the time elapsed since the previous
+// Update (or since the Map was loaded). Call it once per game tick before DrawLayer.
+func (m *Map) Update(at time.Time) {
+	for _, sprite := range m.animated {
+		sprite.Update(at)
+	}
+}
+
+// layer returns the named Layer, or nil if the map has no layer by that name.
+func (m *Map) layer(name string) *Layer {
+	for _, l := range m.layers {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// tilesetForGID returns the resolved tileset containing gid (with flip flags already masked
+// out), along with its index and the tile's local id within that tileset. It reports !ok if gid
+// doesn't fall within any tileset's firstgid..firstgid+tileCount-1 range, e.g. a layer left
+// referencing a tile that was since removed from its tileset without re-exporting the map.
+// Tilesets are assumed to be listed in ascending `firstgid` order, as Tiled itself always writes
+// them.
+func (m *Map) tilesetForGID(gid uint32) (tileset *resolvedTileset, tilesetIndex int, localID int, ok bool) {
+	for i := len(m.tilesets) - 1; i >= 0; i-- {
+		t := m.tilesets[i]
+		if gid < t.firstGID {
+			continue
+		}
+
+		id := int(gid - t.firstGID)
+		if id >= t.tileCount {
+			return nil, 0, 0, false
+		}
+
+		return t, i, id, true
+	}
+	return nil, 0, 0, false
+}
+
+// tileImageAndGeoM resolves a raw layer GID (flip flags and all) to the image it should draw
+// (its animated tile's current frame, if any) and the in-place flip transform those flags
+// describe. It returns a nil image, with no error, for a GID that doesn't resolve to any
+// tileset or in-range tile, so DrawLayer can simply skip that cell.
+func (m *Map) tileImageAndGeoM(gid uint32) (*ebiten.Image, ebiten.GeoM, error) {
+	flipHorizontal := gid&flippedHorizontallyFlag != 0
+	flipVertical := gid&flippedVerticallyFlag != 0
+	flipDiagonal := gid&flippedDiagonallyFlag != 0
+	gid &^= gidFlipMask
+
+	tileset, tilesetIndex, localID, ok := m.tilesetForGID(gid)
+	if !ok {
+		return nil, ebiten.GeoM{}, nil
+	}
+
+	geoM := tileset.flipGeoM(flipHorizontal, flipVertical, flipDiagonal)
+
+	if sprite, ok := m.animated[animatedTileKey{tileset: tilesetIndex, localID: localID}]; ok {
+		if frame := sprite.CurrentFrame(); frame != nil {
+			return frame.Image, geoM, nil
+		}
+	}
+
+	img, err := tileset.tileImage(localID)
+	if err != nil {
+		return nil, geoM, err
+	}
+
+	return img, geoM, nil
+}
+
+// tileGeoM returns the in-place flip transform for a tile with the given Tiled GID flip flags.
+// Called once per flip-flag combination per tileset at load time (see
+// resolvedTileset.precomputeFlipGeoMs), not per draw.
+func tileGeoM(flipHorizontal, flipVertical, flipDiagonal bool, width, height float64) ebiten.GeoM {
+	var g ebiten.GeoM
+
+	if flipDiagonal {
+		// Diagonal flip is a reflection across the tile's main diagonal, (x,y) -> (y,x), not a
+		// rotation (it has determinant -1, a rotation has +1). Rotate(-90deg) alone maps
+		// (x,y) -> (y,-x); the following Scale(1,-1) corrects that back to a mirror by flipping
+		// the sign Rotate introduced, landing exactly on (y,x) with no translate needed. The
+		// tile's occupied box is now `height` wide and `width` tall, so the flips below use the
+		// swapped dimensions.
+		g.Rotate(-math.Pi / 2)
+		g.Scale(1, -1)
+		width, height = height, width
+	}
+
+	if flipHorizontal {
+		g.Scale(-1, 1)
+		g.Translate(width, 0)
+	}
+
+	if flipVertical {
+		g.Scale(1, -1)
+		g.Translate(0, height)
+	}
+
+	return g
+}
+
+// DrawLayer draws every non-empty tile of the named layer onto target as seen through camera.
+// It is a no-op if the layer is hidden. Animated tiles draw whichever frame their internal
+// sprites.Sprite is currently on; call Update before DrawLayer to advance them.
+func (m *Map) DrawLayer(target *ebiten.Image, layerName string, camera Camera) error {
+	layer := m.layer(layerName)
+	if layer == nil {
+		return LayerNotFoundError(layerName)
+	}
+
+	if !layer.Visible {
+		return nil
+	}
+
+	zoom := camera.Zoom
+	if zoom == 0 {
+		zoom = 1
+	}
+
+	for row := 0; row < layer.Height; row++ {
+		for column := 0; column < layer.Width; column++ {
+			gid := layer.gids[row*layer.Width+column]
+			if gid == 0 {
+				continue
+			}
+
+			img, geoM, err := m.tileImageAndGeoM(gid)
+			if err != nil {
+				return err
+			}
+			if img == nil {
+				continue
+			}
+
+			options := new(ebiten.DrawImageOptions)
+			options.GeoM = geoM
+			options.GeoM.Translate(float64(column*m.TileWidth)-camera.X, float64(row*m.TileHeight)-camera.Y)
+			options.GeoM.Scale(zoom, zoom)
+			options.ColorScale.ScaleAlpha(float32(layer.Opacity))
+
+			target.DrawImage(img, options)
+		}
+	}
+
+	return nil
+}
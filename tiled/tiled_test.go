@@ -0,0 +1,61 @@
+package tiled
+
+import (
+	"math"
+	"testing"
+)
+
+// geoMEpsilon tolerates the floating-point error Rotate's sin/cos introduce (e.g. a near-zero
+// term landing at 1e-16 instead of exactly 0), which an exact equality check would flag as a
+// mismatch even though the transform is correct.
+const geoMEpsilon = 1e-9
+
+// TestTileGeoMCornerMapping checks where tileGeoM sends each of a tile's four corners for every
+// one of the 8 possible flip-flag combinations, using a non-square tile (width != height) so a
+// width/height mixup shows up as a wrong coordinate rather than coincidentally matching.
+func TestTileGeoMCornerMapping(t *testing.T) {
+	const width, height = 3.0, 5.0
+
+	type point struct{ x, y float64 }
+
+	corners := struct{ tl, tr, bl, br point }{
+		tl: point{0, 0},
+		tr: point{width, 0},
+		bl: point{0, height},
+		br: point{width, height},
+	}
+
+	cases := []struct {
+		name           string
+		h, v, d        bool
+		tl, tr, bl, br point
+	}{
+		{"none", false, false, false, point{0, 0}, point{3, 0}, point{0, 5}, point{3, 5}},
+		{"H", true, false, false, point{3, 0}, point{0, 0}, point{3, 5}, point{0, 5}},
+		{"V", false, true, false, point{0, 5}, point{3, 5}, point{0, 0}, point{3, 0}},
+		{"H+V", true, true, false, point{3, 5}, point{0, 5}, point{3, 0}, point{0, 0}},
+		{"D", false, false, true, point{0, 0}, point{0, 3}, point{5, 0}, point{5, 3}},
+		{"D+H", true, false, true, point{5, 0}, point{5, 3}, point{0, 0}, point{0, 3}},
+		{"D+V", false, true, true, point{0, 3}, point{0, 0}, point{5, 3}, point{5, 0}},
+		{"D+H+V", true, true, true, point{5, 3}, point{5, 0}, point{0, 3}, point{0, 0}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			g := tileGeoM(c.h, c.v, c.d, width, height)
+
+			check := func(label string, src, want point) {
+				gotX, gotY := g.Apply(src.x, src.y)
+				if math.Abs(gotX-want.x) > geoMEpsilon || math.Abs(gotY-want.y) > geoMEpsilon {
+					t.Errorf("%s corner: g.Apply(%v, %v) = (%v, %v), want (%v, %v)",
+						label, src.x, src.y, gotX, gotY, want.x, want.y)
+				}
+			}
+
+			check("top-left", corners.tl, c.tl)
+			check("top-right", corners.tr, c.tr)
+			check("bottom-left", corners.bl, c.bl)
+			check("bottom-right", corners.br, c.br)
+		})
+	}
+}